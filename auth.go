@@ -0,0 +1,46 @@
+package http_request
+
+import "net/http"
+
+func (c *RequestBuilder) BasicAuth(user, pass string) *RequestBuilder {
+	c.basicAuthUser = user
+	c.basicAuthPass = pass
+	c.hasBasicAuth = true
+	return c
+}
+
+func (c *RequestBuilder) BearerToken(token string) *RequestBuilder {
+	c.bearerToken = token
+	return c
+}
+
+func (c *RequestBuilder) CookieJar(jar http.CookieJar) *RequestBuilder {
+	c.cookieJar = jar
+	return c
+}
+
+func (c *RequestBuilder) Cookie(ck *http.Cookie) *RequestBuilder {
+	c.cookies = append(c.cookies, ck)
+	return c
+}
+
+func (c *RequestBuilder) applyAuth(httpReq *http.Request) {
+	if c.hasBasicAuth {
+		httpReq.SetBasicAuth(c.basicAuthUser, c.basicAuthPass)
+	}
+	if c.bearerToken != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+	for _, ck := range c.cookies {
+		httpReq.AddCookie(ck)
+	}
+}
+
+func (c *RequestBuilder) withCookieJar(client *http.Client) *http.Client {
+	if c.cookieJar == nil {
+		return client
+	}
+	cloneClient := *client
+	cloneClient.Jar = c.cookieJar
+	return &cloneClient
+}
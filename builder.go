@@ -3,6 +3,7 @@ package http_request
 import (
 	"bytes"
 	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type RequestBuilder struct {
@@ -24,8 +26,28 @@ type RequestBuilder struct {
 	logFile string
 
 	enableCompress  bool
+	acceptGzip      bool
 	disableRedirect bool
 	client          *http.Client
+
+	timeout time.Duration
+
+	retryMax       int
+	retryOn        func(resp *http.Response, err error) bool
+	backoffInitial time.Duration
+	backoffMax     time.Duration
+	backoffJitter  bool
+
+	onResponse func(Capture)
+
+	hasBasicAuth  bool
+	basicAuthUser string
+	basicAuthPass string
+	bearerToken   string
+	cookieJar     http.CookieJar
+	cookies       []*http.Cookie
+
+	query url.Values
 }
 
 func New() *RequestBuilder {
@@ -72,6 +94,11 @@ func (c *RequestBuilder) Compressed() *RequestBuilder {
 	return c
 }
 
+func (c *RequestBuilder) AcceptGzip() *RequestBuilder {
+	c.acceptGzip = true
+	return c
+}
+
 func (c *RequestBuilder) DisableRedirect() *RequestBuilder {
 	c.disableRedirect = true
 	return c
@@ -97,96 +124,64 @@ func (c *RequestBuilder) LogFile(logFile string) *RequestBuilder {
 	return c
 }
 
-func (c *RequestBuilder) request(ctx context.Context, url string, post bool, data interface{}, needData bool) (resp []byte, err error) {
+func (c *RequestBuilder) request(ctx context.Context, rawURL string, post bool, data interface{}, needData bool, contentType string) (resp []byte, err error) {
 	if c.buildErr != nil {
 		return nil, c.buildErr
 	}
+	url, err := c.mergeQuery(rawURL)
+	if err != nil {
+		return nil, err
+	}
 	var needLog bool
 	var logDataBytes []byte
 	var logDataString string
 	if c.log || c.logFile != "" {
 		needLog = true
 	}
-	var bodyReader io.Reader
-	var jsonContent bool
+	var reqBodyBytes []byte
+	var hasBody bool
 	var gzipped bool
 	method := "GET"
 	if post {
-		jsonContent = true
+		if contentType == "" {
+			contentType = "application/json"
+		}
 		method = "POST"
 		if data != nil {
+			hasBody = true
 			switch data := data.(type) {
 			case []byte:
-				bodyReader = bytes.NewReader(data)
+				reqBodyBytes = data
 				logDataBytes = data
 			case json.RawMessage:
-				bodyReader = bytes.NewReader(data)
+				reqBodyBytes = data
 				logDataBytes = data
 			case string:
-				bodyReader = strings.NewReader(data)
+				reqBodyBytes = []byte(data)
 				logDataString = data
 			default:
-				var dataBytes []byte
-				dataBytes, err = json.Marshal(data)
+				reqBodyBytes, err = json.Marshal(data)
 				if err != nil {
 					return
 				}
-				bodyReader = bytes.NewReader(dataBytes)
-				logDataBytes = dataBytes
+				logDataBytes = reqBodyBytes
 			}
-			if bodyReader != nil && c.enableCompress {
-				gzipData, gzErr := gzipData(bodyReader)
-				if gzErr != nil {
-					return nil, fmt.Errorf("compress body err: %+v", gzErr)
+			if c.enableCompress {
+				reqBodyBytes, err = gzipData(bytes.NewReader(reqBodyBytes))
+				if err != nil {
+					return nil, fmt.Errorf("compress body err: %+v", err)
 				}
-				bodyReader = bytes.NewReader(gzipData)
 				gzipped = true
 			}
 		}
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
-	if err != nil {
-		return nil, err
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
 	}
-	// apply headers
-	for header, values := range c.header {
-		for _, value := range values {
-			httpReq.Header.Add(header, value)
-		}
-	}
-	if jsonContent {
-		httpReq.Header.Set("Content-Type", "application/json")
-	}
-	if needLog {
-		var args []string
-		args = append(args, "curl", "-v", "-X", method)
-		for k, v := range httpReq.Header {
-			for _, e := range v {
-				args = append(args, "-H", fmt.Sprintf(`"%s: %s"`, k, e))
-			}
-		}
-		if len(logDataBytes) > 0 {
-			args = append(args, "--data-binary", quoteSh(string(logDataBytes)))
-		} else if len(logDataString) > 0 {
-			args = append(args, "--data-binary", quoteSh(logDataString))
-		}
-		args = append(args, quoteSh(url))
 
-		cmdLog := strings.Join(args, " ")
-		if c.logFile != "" {
-			err := ioutil.WriteFile(c.logFile, []byte(cmdLog), 0755)
-			if err != nil {
-				return nil, fmt.Errorf("log err: %w", err)
-			}
-		}
-		if c.log {
-			fmt.Fprintf(os.Stderr, "HTTP DEBUG: %s\n", cmdLog)
-		}
-	}
-	if gzipped {
-		httpReq.Header.Set("Content-Encoding", "gzip")
-	}
 	client := http.DefaultClient
 	if c.client != nil {
 		client = c.client
@@ -198,7 +193,83 @@ func (c *RequestBuilder) request(ctx context.Context, url string, post bool, dat
 		}
 		client = &cloneClient
 	}
-	httpResp, err := client.Do(httpReq)
+	client = c.withCookieJar(client)
+
+	retryOn := c.retryOn
+	if retryOn == nil {
+		retryOn = defaultRetryOn
+	}
+
+	var httpResp *http.Response
+	for attempt := 0; ; attempt++ {
+		var bodyReader io.Reader
+		if hasBody {
+			bodyReader = bytes.NewReader(reqBodyBytes)
+		}
+		httpReq, reqErr := http.NewRequestWithContext(ctx, method, url, bodyReader)
+		if reqErr != nil {
+			return nil, reqErr
+		}
+		// apply headers
+		for header, values := range c.header {
+			for _, value := range values {
+				httpReq.Header.Add(header, value)
+			}
+		}
+		if contentType != "" {
+			httpReq.Header.Set("Content-Type", contentType)
+		}
+		if c.enableCompress || c.acceptGzip {
+			httpReq.Header.Set("Accept-Encoding", "gzip, deflate")
+		}
+		if gzipped {
+			httpReq.Header.Set("Content-Encoding", "gzip")
+		}
+		c.applyAuth(httpReq)
+		if needLog && attempt == 0 {
+			var args []string
+			args = append(args, "curl", "-v", "-X", method)
+			for k, v := range httpReq.Header {
+				for _, e := range v {
+					args = append(args, "-H", fmt.Sprintf(`"%s: %s"`, k, e))
+				}
+			}
+			if len(logDataBytes) > 0 {
+				args = append(args, "--data-binary", quoteSh(string(logDataBytes)))
+			} else if len(logDataString) > 0 {
+				args = append(args, "--data-binary", quoteSh(logDataString))
+			}
+			args = append(args, quoteSh(url))
+
+			cmdLog := strings.Join(args, " ")
+			if c.logFile != "" {
+				err := ioutil.WriteFile(c.logFile, []byte(cmdLog), 0755)
+				if err != nil {
+					return nil, fmt.Errorf("log err: %w", err)
+				}
+			}
+			if c.log {
+				fmt.Fprintf(os.Stderr, "HTTP DEBUG: %s\n", cmdLog)
+			}
+		}
+
+		start := time.Now()
+		httpResp, err = client.Do(httpReq)
+		elapsed := time.Since(start)
+		if c.onResponse != nil {
+			c.captureRoundTrip(httpReq, reqBodyBytes, httpResp, elapsed)
+		}
+		if !retryOn(httpResp, err) || attempt >= c.retryMax {
+			break
+		}
+		if httpResp != nil {
+			io.Copy(ioutil.Discard, httpResp.Body)
+			httpResp.Body.Close()
+		}
+		if waitErr := c.waitBeforeRetry(ctx, httpResp, attempt+1); waitErr != nil {
+			return nil, waitErr
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -207,13 +278,18 @@ func (c *RequestBuilder) request(ctx context.Context, url string, post bool, dat
 	readData := needData
 	var body []byte
 
+	respBody, err := decodeContentEncoding(httpResp)
+	if err != nil {
+		return nil, err
+	}
+
 	if readData {
-		body, err = ioutil.ReadAll(httpResp.Body)
+		body, err = ioutil.ReadAll(respBody)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		io.Copy(ioutil.Discard, httpResp.Body)
+		io.Copy(ioutil.Discard, respBody)
 	}
 	if httpResp.StatusCode >= 300 {
 		return nil, fmt.Errorf("response err: %v %v %v", httpResp.StatusCode, httpResp.Status, string(body))
@@ -228,6 +304,25 @@ func quoteSh(s string) string {
 	return strconv.Quote(s)
 }
 
+func decodeContentEncoding(httpResp *http.Response) (io.ReadCloser, error) {
+	switch strings.ToLower(httpResp.Header.Get("Content-Encoding")) {
+	case "gzip":
+		gzReader, err := gzip.NewReader(httpResp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("decompress gzip response err: %w", err)
+		}
+		return gzReader, nil
+	case "deflate":
+		zReader, err := zlib.NewReader(httpResp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("decompress deflate response err: %w", err)
+		}
+		return zReader, nil
+	default:
+		return httpResp.Body, nil
+	}
+}
+
 func gzipData(reader io.Reader) (compressedData []byte, err error) {
 	var b bytes.Buffer
 	gz := gzip.NewWriter(&b)
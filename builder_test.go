@@ -0,0 +1,77 @@
+package http_request
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAcceptGzipDecodesGzipResponse(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	gw.Write([]byte(`{"msg":"hello"}`))
+	gw.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	var res struct {
+		Msg string `json:"msg"`
+	}
+	err := New().AcceptGzip().Get(context.Background(), srv.URL, &res)
+	if err != nil {
+		t.Fatalf("Get err: %v", err)
+	}
+	if res.Msg != "hello" {
+		t.Errorf("got %q, want %q", res.Msg, "hello")
+	}
+}
+
+func TestAcceptGzipDecodesDeflateResponse(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	zw.Write([]byte(`{"msg":"world"}`))
+	zw.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "deflate")
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	var res struct {
+		Msg string `json:"msg"`
+	}
+	err := New().AcceptGzip().Get(context.Background(), srv.URL, &res)
+	if err != nil {
+		t.Fatalf("Get err: %v", err)
+	}
+	if res.Msg != "world" {
+		t.Errorf("got %q, want %q", res.Msg, "world")
+	}
+}
+
+func TestAcceptGzipPassesThroughPlainResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"msg":"plain"}`))
+	}))
+	defer srv.Close()
+
+	var res struct {
+		Msg string `json:"msg"`
+	}
+	err := New().AcceptGzip().Get(context.Background(), srv.URL, &res)
+	if err != nil {
+		t.Fatalf("Get err: %v", err)
+	}
+	if res.Msg != "plain" {
+		t.Errorf("got %q, want %q", res.Msg, "plain")
+	}
+}
@@ -0,0 +1,115 @@
+package http_request
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type CapturedReq struct {
+	Method string
+	URL    string
+	Header http.Header
+	Body   []byte
+}
+
+type CapturedRes struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+type Capture struct {
+	Req     CapturedReq
+	Res     CapturedRes
+	Elapsed time.Duration
+}
+
+func (c *RequestBuilder) OnResponse(fn func(Capture)) *RequestBuilder {
+	c.onResponse = fn
+	return c
+}
+
+func (c *RequestBuilder) Capture(svc *CaptureService) *RequestBuilder {
+	return c.OnResponse(svc.record)
+}
+
+// Drains and replaces httpResp.Body with a fresh reader so downstream
+// retry/decode logic can still consume it.
+func (c *RequestBuilder) captureRoundTrip(httpReq *http.Request, reqBody []byte, httpResp *http.Response, elapsed time.Duration) {
+	rec := Capture{
+		Req: CapturedReq{
+			Method: httpReq.Method,
+			URL:    httpReq.URL.String(),
+			Header: httpReq.Header.Clone(),
+			Body:   reqBody,
+		},
+		Elapsed: elapsed,
+	}
+	if httpResp != nil {
+		resBody, _ := ioutil.ReadAll(httpResp.Body)
+		httpResp.Body.Close()
+		httpResp.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+		rec.Res = CapturedRes{
+			StatusCode: httpResp.StatusCode,
+			Header:     httpResp.Header.Clone(),
+			Body:       resBody,
+		}
+	}
+	c.onResponse(rec)
+}
+
+func (c *RequestBuilder) captureStreamRoundTrip(httpReq *http.Request, httpResp *http.Response, elapsed time.Duration) {
+	rec := Capture{
+		Req: CapturedReq{
+			Method: httpReq.Method,
+			URL:    httpReq.URL.String(),
+			Header: httpReq.Header.Clone(),
+		},
+		Elapsed: elapsed,
+	}
+	if httpResp != nil {
+		rec.Res = CapturedRes{
+			StatusCode: httpResp.StatusCode,
+			Header:     httpResp.Header.Clone(),
+		}
+	}
+	c.onResponse(rec)
+}
+
+type CaptureService struct {
+	mu    sync.Mutex
+	items []Capture
+	size  int
+	start int
+}
+
+func NewCaptureService(size int) *CaptureService {
+	return &CaptureService{size: size}
+}
+
+func (s *CaptureService) record(rec Capture) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size <= 0 {
+		return
+	}
+	if len(s.items) < s.size {
+		s.items = append(s.items, rec)
+		return
+	}
+	s.items[s.start] = rec
+	s.start = (s.start + 1) % s.size
+}
+
+func (s *CaptureService) Recent() []Capture {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Capture, len(s.items))
+	for i := range s.items {
+		out[i] = s.items[(s.start+i)%len(s.items)]
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package http_request
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+func (c *RequestBuilder) Query(name, value string) *RequestBuilder {
+	if c.query == nil {
+		c.query = url.Values{}
+	}
+	c.query.Add(name, value)
+	return c
+}
+
+func (c *RequestBuilder) QueryStruct(v interface{}) *RequestBuilder {
+	if c.buildErr != nil {
+		return c
+	}
+	values, err := structToQuery(v)
+	if err != nil {
+		c.buildErr = err
+		return c
+	}
+	if c.query == nil {
+		c.query = url.Values{}
+	}
+	for name, vals := range values {
+		c.query[name] = append(c.query[name], vals...)
+	}
+	return c
+}
+
+func (c *RequestBuilder) mergeQuery(rawURL string) (string, error) {
+	if len(c.query) == 0 {
+		return rawURL, nil
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("parse url err: %w", err)
+	}
+	existing := parsed.Query()
+	for name, vals := range c.query {
+		existing[name] = append(existing[name], vals...)
+	}
+	parsed.RawQuery = existing.Encode()
+	return parsed.String(), nil
+}
+
+func structToQuery(v interface{}) (url.Values, error) {
+	values := url.Values{}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return values, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("QueryStruct: expected struct or pointer to struct, got %T", v)
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		name := field.Name
+		omitEmpty := false
+		if tag, ok := field.Tag.Lookup("url"); ok {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" {
+				continue
+			}
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, opt := range parts[1:] {
+				if opt == "omitempty" {
+					omitEmpty = true
+				}
+			}
+		}
+		fv := rv.Field(i)
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+		for fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				break
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Ptr {
+			continue // nil pointer, no value to encode
+		}
+		values.Add(name, fmt.Sprintf("%v", fv.Interface()))
+	}
+	return values, nil
+}
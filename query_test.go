@@ -0,0 +1,78 @@
+package http_request
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestStructToQuery(t *testing.T) {
+	name := "alice"
+
+	cases := []struct {
+		name string
+		in   interface{}
+		want url.Values
+	}{
+		{
+			name: "pointer field set",
+			in: &struct {
+				Name *string `url:"name,omitempty"`
+			}{Name: &name},
+			want: url.Values{"name": {"alice"}},
+		},
+		{
+			name: "pointer field nil with omitempty",
+			in: &struct {
+				Name *string `url:"name,omitempty"`
+			}{Name: nil},
+			want: url.Values{},
+		},
+		{
+			name: "pointer field nil without omitempty",
+			in: &struct {
+				Name *string `url:"name"`
+			}{Name: nil},
+			want: url.Values{},
+		},
+		{
+			name: "plain string field",
+			in: &struct {
+				Name string `url:"name"`
+			}{Name: "bob"},
+			want: url.Values{"name": {"bob"}},
+		},
+		{
+			name: "untagged field uses Go name",
+			in: &struct {
+				Page int
+			}{Page: 2},
+			want: url.Values{"Page": {"2"}},
+		},
+		{
+			name: "dash tag is skipped",
+			in: &struct {
+				Secret string `url:"-"`
+			}{Secret: "hidden"},
+			want: url.Values{},
+		},
+		{
+			name: "zero value with omitempty is skipped",
+			in: &struct {
+				Count int `url:"count,omitempty"`
+			}{Count: 0},
+			want: url.Values{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := structToQuery(tc.in)
+			if err != nil {
+				t.Fatalf("structToQuery err: %v", err)
+			}
+			if got.Encode() != tc.want.Encode() {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
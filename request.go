@@ -3,6 +3,7 @@ package http_request
 import (
 	"context"
 	"errors"
+	"net/url"
 )
 
 var ErrRedirect = errors.New("redirect")
@@ -10,7 +11,25 @@ var ErrRedirect = errors.New("redirect")
 type PlainHtml string
 
 func (c *RequestBuilder) PostJSON(ctx context.Context, url string, data interface{}, res interface{}) error {
-	body, err := c.request(ctx, url, true, data, res != nil)
+	body, err := c.request(ctx, url, true, data, res != nil, "")
+	if err != nil {
+		return err
+	}
+	if res == nil || len(body) == 0 {
+		return nil
+	}
+	if p, ok := res.(*PlainHtml); ok {
+		*p = PlainHtml(body)
+		return nil
+	}
+	return unmarshalSafeNumber(body, res)
+}
+
+// PostForm posts values as application/x-www-form-urlencoded, the content
+// type expected by the large universe of form-based endpoints, as opposed
+// to PostJSON's application/json.
+func (c *RequestBuilder) PostForm(ctx context.Context, url string, values url.Values, res interface{}) error {
+	body, err := c.request(ctx, url, true, values.Encode(), res != nil, "application/x-www-form-urlencoded")
 	if err != nil {
 		return err
 	}
@@ -25,7 +44,7 @@ func (c *RequestBuilder) PostJSON(ctx context.Context, url string, data interfac
 }
 
 func (c *RequestBuilder) Get(ctx context.Context, url string, res interface{}) error {
-	body, err := c.request(ctx, url, false, nil, res != nil)
+	body, err := c.request(ctx, url, false, nil, res != nil, "")
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,102 @@
+package http_request
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultBackoffInitial = 200 * time.Millisecond
+	defaultBackoffMax     = 5 * time.Second
+)
+
+func (c *RequestBuilder) Timeout(d time.Duration) *RequestBuilder {
+	c.timeout = d
+	return c
+}
+
+func (c *RequestBuilder) Retry(max int) *RequestBuilder {
+	c.retryMax = max
+	return c
+}
+
+func (c *RequestBuilder) RetryOn(fn func(resp *http.Response, err error) bool) *RequestBuilder {
+	c.retryOn = fn
+	return c
+}
+
+func (c *RequestBuilder) Backoff(initial, max time.Duration, jitter bool) *RequestBuilder {
+	c.backoffInitial = initial
+	c.backoffMax = max
+	c.backoffJitter = jitter
+	return c
+}
+
+func defaultRetryOn(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	if resp == nil {
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+func (c *RequestBuilder) backoffDelay(attempt int) time.Duration {
+	initial := c.backoffInitial
+	if initial <= 0 {
+		initial = defaultBackoffInitial
+	}
+	max := c.backoffMax
+	if max <= 0 {
+		max = defaultBackoffMax
+	}
+	delay := initial << uint(attempt-1)
+	if delay <= 0 || delay > max {
+		delay = max
+	}
+	if c.backoffJitter {
+		delay = time.Duration(rand.Int63n(int64(delay) + 1))
+	}
+	return delay
+}
+
+func (c *RequestBuilder) waitBeforeRetry(ctx context.Context, resp *http.Response, attempt int) error {
+	delay := c.backoffDelay(attempt)
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			delay = retryAfter
+		}
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		d := time.Until(when)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
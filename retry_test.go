@@ -0,0 +1,69 @@
+package http_request
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	c := New().Backoff(100*time.Millisecond, time.Second, false)
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second}, // capped at max
+		{6, time.Second},
+	}
+	for _, tc := range cases {
+		got := c.backoffDelay(tc.attempt)
+		if got != tc.want {
+			t.Errorf("attempt %d: got %v, want %v", tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestBackoffDelayJitter(t *testing.T) {
+	c := New().Backoff(100*time.Millisecond, time.Second, true)
+	for i := 0; i < 20; i++ {
+		got := c.backoffDelay(3)
+		if got < 0 || got > 400*time.Millisecond {
+			t.Fatalf("jittered delay out of range: %v", got)
+		}
+	}
+}
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	d, ok := parseRetryAfter("5")
+	if !ok || d != 5*time.Second {
+		t.Fatalf("got %v, %v; want 5s, true", d, ok)
+	}
+}
+
+func TestParseRetryAfterHTTPDate(t *testing.T) {
+	when := time.Now().Add(10 * time.Second).UTC()
+	d, ok := parseRetryAfter(when.Format(http.TimeFormat))
+	if !ok {
+		t.Fatal("expected ok")
+	}
+	if d <= 0 || d > 10*time.Second {
+		t.Fatalf("got %v, want ~10s", d)
+	}
+}
+
+func TestParseRetryAfterInvalid(t *testing.T) {
+	if _, ok := parseRetryAfter(""); ok {
+		t.Fatal("expected not ok for empty value")
+	}
+	if _, ok := parseRetryAfter("not-a-date"); ok {
+		t.Fatal("expected not ok for garbage value")
+	}
+	if _, ok := parseRetryAfter("-5"); ok {
+		t.Fatal("expected not ok for negative seconds")
+	}
+}
@@ -0,0 +1,178 @@
+package http_request
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+type ResponseDecoder interface {
+	Decode(r io.Reader) error
+}
+
+type CountingReader struct {
+	r      io.Reader
+	n      int64
+	onRead func(total int64)
+}
+
+func NewCountingReader(r io.Reader, onRead func(total int64)) *CountingReader {
+	return &CountingReader{r: r, onRead: onRead}
+}
+
+func (cr *CountingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	if n > 0 {
+		cr.n += int64(n)
+		if cr.onRead != nil {
+			cr.onRead(cr.n)
+		}
+	}
+	return n, err
+}
+
+func (cr *CountingReader) N() int64 {
+	return cr.n
+}
+
+func (c *RequestBuilder) PostStream(ctx context.Context, url string, body io.Reader, res interface{}) error {
+	respBody, err := c.requestStream(ctx, url, http.MethodPost, body)
+	if err != nil {
+		return err
+	}
+	defer respBody.Close()
+	return decodeStreamInto(respBody, res)
+}
+
+func (c *RequestBuilder) GetStream(ctx context.Context, url string) (io.ReadCloser, http.Header, error) {
+	httpResp, respBody, err := c.doStream(ctx, url, http.MethodGet, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return respBody, httpResp.Header, nil
+}
+
+func (c *RequestBuilder) requestStream(ctx context.Context, url string, method string, body io.Reader) (io.ReadCloser, error) {
+	_, respBody, err := c.doStream(ctx, url, method, body)
+	return respBody, err
+}
+
+func (c *RequestBuilder) doStream(ctx context.Context, rawURL string, method string, body io.Reader) (*http.Response, io.ReadCloser, error) {
+	if c.buildErr != nil {
+		return nil, nil, c.buildErr
+	}
+	url, err := c.mergeQuery(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	for header, values := range c.header {
+		for _, value := range values {
+			httpReq.Header.Add(header, value)
+		}
+	}
+	if method == http.MethodPost {
+		httpReq.Header.Set("Content-Type", "application/octet-stream")
+	}
+	if c.enableCompress || c.acceptGzip {
+		httpReq.Header.Set("Accept-Encoding", "gzip, deflate")
+	}
+	c.applyAuth(httpReq)
+
+	client := http.DefaultClient
+	if c.client != nil {
+		client = c.client
+	}
+	if c.disableRedirect {
+		cloneClient := *client
+		cloneClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return ErrRedirect
+		}
+		client = &cloneClient
+	}
+	client = c.withCookieJar(client)
+
+	start := time.Now()
+	httpResp, err := client.Do(httpReq)
+	elapsed := time.Since(start)
+	if c.onResponse != nil {
+		c.captureStreamRoundTrip(httpReq, httpResp, elapsed)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	decodedBody, err := decodeContentEncoding(httpResp)
+	if err != nil {
+		httpResp.Body.Close()
+		return nil, nil, err
+	}
+	respBody := closeBoth(decodedBody, httpResp.Body)
+	if httpResp.StatusCode >= 300 {
+		defer respBody.Close()
+		errBody, _ := ioutil.ReadAll(respBody)
+		return nil, nil, fmt.Errorf("response err: %v %v %v", httpResp.StatusCode, httpResp.Status, string(errBody))
+	}
+	return httpResp, respBody, nil
+}
+
+// gzip.Reader/zlib's Close don't close the underlying reader, so dec alone
+// wouldn't release the connection backing raw.
+func closeBoth(dec io.ReadCloser, raw io.ReadCloser) io.ReadCloser {
+	if dec == raw {
+		return dec
+	}
+	return &multiCloseBody{Reader: dec, dec: dec, raw: raw}
+}
+
+type multiCloseBody struct {
+	io.Reader
+	dec io.Closer
+	raw io.Closer
+}
+
+func (b *multiCloseBody) Close() error {
+	err := b.dec.Close()
+	if rawErr := b.raw.Close(); err == nil {
+		err = rawErr
+	}
+	return err
+}
+
+func decodeStreamInto(respBody io.Reader, res interface{}) error {
+	if res == nil {
+		_, err := io.Copy(ioutil.Discard, respBody)
+		return err
+	}
+	if p, ok := res.(*PlainHtml); ok {
+		body, err := ioutil.ReadAll(respBody)
+		if err != nil {
+			return err
+		}
+		*p = PlainHtml(body)
+		return nil
+	}
+	if w, ok := res.(io.Writer); ok {
+		_, err := io.Copy(w, respBody)
+		return err
+	}
+	if dec, ok := res.(ResponseDecoder); ok {
+		return dec.Decode(respBody)
+	}
+	body, err := ioutil.ReadAll(respBody)
+	if err != nil {
+		return err
+	}
+	return unmarshalSafeNumber(body, res)
+}